@@ -0,0 +1,118 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package crdloader
+
+import (
+	"reflect"
+	"sort"
+
+	"github.com/elastic/crd-ref-docs/processor"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+// Divergence records that two aliased groups defined the same Kind with
+// schemas that don't match, so the renderer can surface a diff annotation
+// instead of silently preferring one definition.
+type Divergence struct {
+	// Group is the canonical name of the merged group the divergence was
+	// found in.
+	Group string
+	// Kind is the Kind that was defined more than once with different
+	// schemas.
+	Kind string
+	// Variants holds each distinct Type definition seen for Kind, in the
+	// order they were first encountered.
+	Variants []Type
+}
+
+// MergeAliasedGroups collapses the GroupVersionDetails whose group belongs
+// to the same GroupAliasSet into a single entry under its canonical name
+// (see processor.CanonicalGroup), de-duplicating identical Kinds/Versions.
+// When aliased groups define the same Kind with a divergent schema, only the
+// first definition seen is kept as the merged Type, and a Divergence holding
+// every distinct definition is returned per such Kind so callers can
+// annotate the rendered doc instead of silently picking one.
+//
+// aliasSets is typically obtained from a config file's "aliases" key via
+// processor.AliasesConfig.GroupAliasSets, e.g.:
+//
+//	aliases:
+//	  - ["networking.k8s.io", "networking.gke.io"]
+func MergeAliasedGroups(details []GroupVersionDetails, aliasSets []processor.GroupAliasSet) ([]GroupVersionDetails, []Divergence) {
+	var order []schema.GroupVersion
+	variantsByGV := map[schema.GroupVersion]map[string][]Type{}
+
+	for _, d := range details {
+		canonical := schema.GroupVersion{
+			Group:   processor.CanonicalGroup(d.Group, aliasSets),
+			Version: d.Version,
+		}
+
+		byKind, ok := variantsByGV[canonical]
+		if !ok {
+			byKind = map[string][]Type{}
+			variantsByGV[canonical] = byKind
+			order = append(order, canonical)
+		}
+
+		for _, t := range d.Types {
+			if !containsType(byKind[t.Kind], t) {
+				byKind[t.Kind] = append(byKind[t.Kind], t)
+			}
+		}
+	}
+
+	var merged []GroupVersionDetails
+	var divergences []Divergence
+
+	for _, gv := range order {
+		byKind := variantsByGV[gv]
+
+		kinds := make([]string, 0, len(byKind))
+		for kind := range byKind {
+			kinds = append(kinds, kind)
+		}
+		sort.Strings(kinds)
+
+		gvd := GroupVersionDetails{GroupVersion: gv}
+		for _, kind := range kinds {
+			variants := byKind[kind]
+			gvd.Types = append(gvd.Types, variants[0])
+			if len(variants) > 1 {
+				divergences = append(divergences, Divergence{
+					Group:    gv.Group,
+					Kind:     kind,
+					Variants: variants,
+				})
+			}
+		}
+
+		merged = append(merged, gvd)
+	}
+
+	return merged, divergences
+}
+
+func containsType(variants []Type, t Type) bool {
+	for _, v := range variants {
+		if reflect.DeepEqual(v, t) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,91 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package crdloader
+
+import (
+	"testing"
+
+	"github.com/elastic/crd-ref-docs/processor"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+func TestMergeAliasedGroupsDeduplicatesIdenticalKinds(t *testing.T) {
+	aliasSets := []processor.GroupAliasSet{
+		{"networking.k8s.io", "networking.gke.io"},
+	}
+
+	details := []GroupVersionDetails{
+		{
+			GroupVersion: schema.GroupVersion{Group: "networking.k8s.io", Version: "v1"},
+			Types:        []Type{{Kind: "Route", Fields: []Field{{Name: "host", Type: "string"}}}},
+		},
+		{
+			GroupVersion: schema.GroupVersion{Group: "networking.gke.io", Version: "v1"},
+			Types:        []Type{{Kind: "Route", Fields: []Field{{Name: "host", Type: "string"}}}},
+		},
+	}
+
+	merged, divergences := MergeAliasedGroups(details, aliasSets)
+
+	if len(merged) != 1 {
+		t.Fatalf("expected 1 merged GroupVersionDetails, got %d", len(merged))
+	}
+	if merged[0].Group != "networking.k8s.io" {
+		t.Errorf("merged group = %q, want canonical %q", merged[0].Group, "networking.k8s.io")
+	}
+	if len(merged[0].Types) != 1 {
+		t.Fatalf("expected identical Route definitions to dedupe to 1 Type, got %d", len(merged[0].Types))
+	}
+	if len(divergences) != 0 {
+		t.Errorf("expected no divergences for identical schemas, got %v", divergences)
+	}
+}
+
+func TestMergeAliasedGroupsReportsDivergence(t *testing.T) {
+	aliasSets := []processor.GroupAliasSet{
+		{"networking.k8s.io", "networking.gke.io"},
+	}
+
+	details := []GroupVersionDetails{
+		{
+			GroupVersion: schema.GroupVersion{Group: "networking.k8s.io", Version: "v1"},
+			Types:        []Type{{Kind: "Route", Fields: []Field{{Name: "host", Type: "string"}}}},
+		},
+		{
+			GroupVersion: schema.GroupVersion{Group: "networking.gke.io", Version: "v1"},
+			Types:        []Type{{Kind: "Route", Fields: []Field{{Name: "host", Type: "string"}, {Name: "path", Type: "string"}}}},
+		},
+	}
+
+	merged, divergences := MergeAliasedGroups(details, aliasSets)
+
+	if len(merged) != 1 || len(merged[0].Types) != 1 {
+		t.Fatalf("expected the divergent Kind to still merge into 1 entry, got %+v", merged)
+	}
+	if len(divergences) != 1 {
+		t.Fatalf("expected 1 divergence, got %d", len(divergences))
+	}
+
+	d := divergences[0]
+	if d.Group != "networking.k8s.io" || d.Kind != "Route" {
+		t.Errorf("divergence = %+v, want Group=networking.k8s.io Kind=Route", d)
+	}
+	if len(d.Variants) != 2 {
+		t.Errorf("expected 2 divergent variants recorded, got %d", len(d.Variants))
+	}
+}
@@ -0,0 +1,81 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package crdloader
+
+import (
+	"context"
+	"fmt"
+
+	apiextensionsclientset "k8s.io/apiextensions-apiserver/pkg/client/clientset/clientset"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/clientcmd"
+)
+
+// ClusterLoader loads CustomResourceDefinitions from a live cluster via the
+// apiextensions-apiserver clientset.
+type ClusterLoader struct {
+	client        apiextensionsclientset.Interface
+	labelSelector string
+}
+
+// NewClusterLoader builds a ClusterLoader from a kubeconfig file and
+// context, both of which may be empty to use client-go's usual defaults
+// (KUBECONFIG / in-cluster config, and the kubeconfig's current context).
+// labelSelector restricts which CRDs are loaded; an empty selector loads
+// all of them.
+func NewClusterLoader(kubeconfigPath, kubeContext, labelSelector string) (*ClusterLoader, error) {
+	loadingRules := clientcmd.NewDefaultClientConfigLoadingRules()
+	if kubeconfigPath != "" {
+		loadingRules.ExplicitPath = kubeconfigPath
+	}
+
+	overrides := &clientcmd.ConfigOverrides{}
+	if kubeContext != "" {
+		overrides.CurrentContext = kubeContext
+	}
+
+	restConfig, err := clientcmd.NewNonInteractiveDeferredLoadingClientConfig(loadingRules, overrides).ClientConfig()
+	if err != nil {
+		return nil, fmt.Errorf("building kubeconfig client config: %w", err)
+	}
+
+	client, err := apiextensionsclientset.NewForConfig(restConfig)
+	if err != nil {
+		return nil, fmt.Errorf("building apiextensions clientset: %w", err)
+	}
+
+	return &ClusterLoader{client: client, labelSelector: labelSelector}, nil
+}
+
+// Load lists CustomResourceDefinitions from the cluster, filtered by the
+// loader's label selector, and converts each into GroupVersionDetails.
+func (l *ClusterLoader) Load(ctx context.Context) ([]GroupVersionDetails, error) {
+	list, err := l.client.ApiextensionsV1().CustomResourceDefinitions().List(ctx, metav1.ListOptions{
+		LabelSelector: l.labelSelector,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("listing CustomResourceDefinitions: %w", err)
+	}
+
+	var details []GroupVersionDetails
+	for i := range list.Items {
+		details = append(details, groupVersionsFromCRD(&list.Items[i])...)
+	}
+
+	return mergeGroupVersions(details), nil
+}
@@ -0,0 +1,126 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package crdloader
+
+import (
+	"context"
+	"testing"
+
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	apiextensionsfake "k8s.io/apiextensions-apiserver/pkg/client/clientset/clientset/fake"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+func widgetCRD(name, group string, labels map[string]string) *apiextensionsv1.CustomResourceDefinition {
+	return &apiextensionsv1.CustomResourceDefinition{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Labels: labels},
+		Spec: apiextensionsv1.CustomResourceDefinitionSpec{
+			Group: group,
+			Names: apiextensionsv1.CustomResourceDefinitionNames{Kind: "Widget", Plural: "widgets"},
+			Scope: apiextensionsv1.NamespaceScoped,
+			Versions: []apiextensionsv1.CustomResourceDefinitionVersion{
+				{
+					Name:    "v1",
+					Served:  true,
+					Storage: true,
+					Schema: &apiextensionsv1.CustomResourceValidation{
+						OpenAPIV3Schema: &apiextensionsv1.JSONSchemaProps{
+							Type: "object",
+							Properties: map[string]apiextensionsv1.JSONSchemaProps{
+								"size": {Type: "integer"},
+							},
+						},
+					},
+				},
+				{
+					Name:    "v1beta1",
+					Served:  false,
+					Storage: false,
+					Schema: &apiextensionsv1.CustomResourceValidation{
+						OpenAPIV3Schema: &apiextensionsv1.JSONSchemaProps{
+							Type: "object",
+							Properties: map[string]apiextensionsv1.JSONSchemaProps{
+								"size": {Type: "integer"},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func TestClusterLoaderLoadConvertsServedAndUnservedVersions(t *testing.T) {
+	client := apiextensionsfake.NewSimpleClientset(widgetCRD("widgets.example.com", "example.com", nil))
+	loader := &ClusterLoader{client: client}
+
+	details, err := loader.Load(context.Background())
+	if err != nil {
+		t.Fatalf("Load() returned error: %v", err)
+	}
+
+	if len(details) != 2 {
+		t.Fatalf("expected 2 GroupVersionDetails (v1, v1beta1), got %d", len(details))
+	}
+
+	byVersion := make(map[string]GroupVersionDetails, len(details))
+	for _, d := range details {
+		byVersion[d.Version] = d
+	}
+
+	v1, ok := byVersion["v1"]
+	if !ok || len(v1.Types) != 1 || !v1.Types[0].Served || !v1.Types[0].Storage {
+		t.Errorf("v1 details = %+v, want 1 served+storage Widget Type", v1)
+	}
+
+	v1beta1, ok := byVersion["v1beta1"]
+	if !ok || len(v1beta1.Types) != 1 || v1beta1.Types[0].Served || v1beta1.Types[0].Storage {
+		t.Errorf("v1beta1 details = %+v, want 1 unserved, non-storage Widget Type", v1beta1)
+	}
+}
+
+func TestClusterLoaderLoadFiltersByLabelSelector(t *testing.T) {
+	matching := widgetCRD("widgets.example.com", "example.com", map[string]string{"team": "platform"})
+	nonMatching := widgetCRD("gadgets.example.com", "other.example.com", map[string]string{"team": "apps"})
+
+	client := apiextensionsfake.NewSimpleClientset(matching, nonMatching)
+	loader := &ClusterLoader{client: client, labelSelector: "team=platform"}
+
+	details, err := loader.Load(context.Background())
+	if err != nil {
+		t.Fatalf("Load() returned error: %v", err)
+	}
+
+	for _, d := range details {
+		if d.GroupVersion == (schema.GroupVersion{Group: "other.example.com", Version: "v1beta1"}) ||
+			d.GroupVersion == (schema.GroupVersion{Group: "other.example.com", Version: "v1"}) {
+			t.Fatalf("Load() included CRD excluded by label selector: %+v", d)
+		}
+	}
+
+	found := false
+	for _, d := range details {
+		if d.Group == "example.com" && d.Version == "v1" {
+			found = true
+		}
+	}
+	if !found {
+		t.Fatalf("Load() missing the CRD matching the label selector, got %+v", details)
+	}
+}
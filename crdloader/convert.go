@@ -0,0 +1,129 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package crdloader
+
+import (
+	"sort"
+
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+// groupVersionsFromCRD converts a single CustomResourceDefinition into one
+// GroupVersionDetails per entry of spec.versions, served or not, reading each version's
+// embedded OpenAPI v3 schema at spec.versions[*].schema.openAPIV3Schema.
+// Versions without a schema are skipped, since there's nothing to render.
+func groupVersionsFromCRD(crd *apiextensionsv1.CustomResourceDefinition) []GroupVersionDetails {
+	var details []GroupVersionDetails
+
+	for _, version := range crd.Spec.Versions {
+		if version.Schema == nil || version.Schema.OpenAPIV3Schema == nil {
+			continue
+		}
+
+		t := Type{
+			Kind:    crd.Spec.Names.Kind,
+			Doc:     version.Schema.OpenAPIV3Schema.Description,
+			Fields:  fieldsFromSchema(version.Schema.OpenAPIV3Schema),
+			Served:  version.Served,
+			Storage: version.Storage,
+		}
+
+		details = append(details, GroupVersionDetails{
+			GroupVersion: schema.GroupVersion{Group: crd.Spec.Group, Version: version.Name},
+			Types:        []Type{t},
+		})
+	}
+
+	return details
+}
+
+// fieldsFromSchema converts a schema's properties into Fields, sorted by name
+// for deterministic rendering. A property that is itself an object, or an
+// array whose items are objects, is converted recursively into a nested
+// Type rather than flattened, since real CRD schemas are virtually always
+// nested (spec.template.spec.containers[].image, and so on).
+func fieldsFromSchema(s *apiextensionsv1.JSONSchemaProps) []Field {
+	if len(s.Properties) == 0 {
+		return nil
+	}
+
+	required := make(map[string]bool, len(s.Required))
+	for _, name := range s.Required {
+		required[name] = true
+	}
+
+	fields := make([]Field, 0, len(s.Properties))
+	for name, prop := range s.Properties {
+		prop := prop
+		fields = append(fields, fieldFromProperty(name, prop, required[name]))
+	}
+
+	sort.Slice(fields, func(i, j int) bool { return fields[i].Name < fields[j].Name })
+	return fields
+}
+
+// fieldFromProperty converts a single named schema property into a Field,
+// recursing into nestedTypeFromProperty when the property describes an
+// object or an array of objects.
+func fieldFromProperty(name string, prop apiextensionsv1.JSONSchemaProps, required bool) Field {
+	return Field{
+		Name:     name,
+		Doc:      prop.Description,
+		Type:     prop.Type,
+		Required: required,
+		Nested:   nestedTypeFromProperty(prop),
+	}
+}
+
+// nestedTypeFromProperty returns the Type describing prop's own properties
+// when prop is an object, or the Type describing the properties of its
+// array elements when prop is an array of objects. It returns nil for
+// scalars and for objects/arrays with no declared properties, since there's
+// nothing to recurse into.
+func nestedTypeFromProperty(prop apiextensionsv1.JSONSchemaProps) *Type {
+	switch {
+	case prop.Type == "object" && len(prop.Properties) > 0:
+		return &Type{Doc: prop.Description, Fields: fieldsFromSchema(&prop)}
+	case prop.Type == "array" && prop.Items != nil && prop.Items.Schema != nil && len(prop.Items.Schema.Properties) > 0:
+		items := prop.Items.Schema
+		return &Type{Doc: items.Description, Fields: fieldsFromSchema(items)}
+	default:
+		return nil
+	}
+}
+
+// mergeGroupVersions combines the per-CRD GroupVersionDetails produced by
+// groupVersionsFromCRD, concatenating Types for entries that share a
+// GroupVersion so that multiple Kinds under the same group version render
+// as a single section.
+func mergeGroupVersions(details []GroupVersionDetails) []GroupVersionDetails {
+	index := make(map[schema.GroupVersion]int)
+	var merged []GroupVersionDetails
+
+	for _, d := range details {
+		if i, ok := index[d.GroupVersion]; ok {
+			merged[i].Types = append(merged[i].Types, d.Types...)
+			continue
+		}
+		index[d.GroupVersion] = len(merged)
+		merged = append(merged, d)
+	}
+
+	return merged
+}
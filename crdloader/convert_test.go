@@ -0,0 +1,106 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package crdloader
+
+import (
+	"testing"
+
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+)
+
+func TestFieldsFromSchemaRecursesIntoNestedObject(t *testing.T) {
+	s := &apiextensionsv1.JSONSchemaProps{
+		Type: "object",
+		Properties: map[string]apiextensionsv1.JSONSchemaProps{
+			"spec": {
+				Type: "object",
+				Properties: map[string]apiextensionsv1.JSONSchemaProps{
+					"replicas": {Type: "integer"},
+				},
+			},
+		},
+	}
+
+	fields := fieldsFromSchema(s)
+	if len(fields) != 1 {
+		t.Fatalf("expected 1 field, got %d", len(fields))
+	}
+
+	spec := fields[0]
+	if spec.Name != "spec" || spec.Type != "object" {
+		t.Fatalf("fields[0] = %+v, want spec/object", spec)
+	}
+	if spec.Nested == nil {
+		t.Fatal("expected spec.Nested to hold the nested Type, got nil")
+	}
+	if len(spec.Nested.Fields) != 1 || spec.Nested.Fields[0].Name != "replicas" {
+		t.Errorf("spec.Nested.Fields = %+v, want a single replicas field", spec.Nested.Fields)
+	}
+}
+
+func TestFieldsFromSchemaRecursesIntoArrayOfObjects(t *testing.T) {
+	s := &apiextensionsv1.JSONSchemaProps{
+		Type: "object",
+		Properties: map[string]apiextensionsv1.JSONSchemaProps{
+			"containers": {
+				Type: "array",
+				Items: &apiextensionsv1.JSONSchemaPropsOrArray{
+					Schema: &apiextensionsv1.JSONSchemaProps{
+						Type: "object",
+						Properties: map[string]apiextensionsv1.JSONSchemaProps{
+							"image": {Type: "string"},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	fields := fieldsFromSchema(s)
+	if len(fields) != 1 {
+		t.Fatalf("expected 1 field, got %d", len(fields))
+	}
+
+	containers := fields[0]
+	if containers.Name != "containers" || containers.Type != "array" {
+		t.Fatalf("fields[0] = %+v, want containers/array", containers)
+	}
+	if containers.Nested == nil {
+		t.Fatal("expected containers.Nested to hold the element Type, got nil")
+	}
+	if len(containers.Nested.Fields) != 1 || containers.Nested.Fields[0].Name != "image" {
+		t.Errorf("containers.Nested.Fields = %+v, want a single image field", containers.Nested.Fields)
+	}
+}
+
+func TestFieldsFromSchemaScalarHasNoNested(t *testing.T) {
+	s := &apiextensionsv1.JSONSchemaProps{
+		Type: "object",
+		Properties: map[string]apiextensionsv1.JSONSchemaProps{
+			"size": {Type: "integer"},
+		},
+	}
+
+	fields := fieldsFromSchema(s)
+	if len(fields) != 1 {
+		t.Fatalf("expected 1 field, got %d", len(fields))
+	}
+	if fields[0].Nested != nil {
+		t.Errorf("expected scalar field to have nil Nested, got %+v", fields[0].Nested)
+	}
+}
@@ -0,0 +1,86 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+// Package crdloader builds the same GroupVersionDetails/Type structures the
+// rendering templates expect, but sources them from CustomResourceDefinition
+// schemas instead of gengo-parsed Go packages. This lets reference docs be
+// generated for controllers whose Go types aren't available, such as
+// third-party CRDs or Helm-installed operators, by reading either a live
+// cluster (ClusterLoader) or a directory of CRD YAML manifests
+// (ManifestLoader).
+//
+// This package only provides the Loader implementations. Nothing in this
+// tree yet wires a --source=cluster CLI flag, kubeconfig/context selection,
+// or a manifest-directory flag to them; that CLI/config surface doesn't
+// exist here at all and is tracked as a follow-up in ../TODO.md rather than
+// assumed to exist.
+package crdloader
+
+import (
+	"context"
+
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+// Loader produces GroupVersionDetails from CustomResourceDefinitions,
+// regardless of where those definitions come from.
+type Loader interface {
+	Load(ctx context.Context) ([]GroupVersionDetails, error)
+}
+
+// GroupVersionDetails collects the Types rendered under a single API group
+// version, keyed by schema.GroupVersion so that package processor's
+// hierarchical group/version sorting applies to loader output unchanged.
+type GroupVersionDetails struct {
+	schema.GroupVersion
+
+	// Types are the Kinds defined for this group version, one per CRD
+	// version schema.
+	Types []Type
+}
+
+// Type is a single Kind rendered from a CRD version's OpenAPI v3 schema.
+type Type struct {
+	// Kind is the CRD's spec.names.kind.
+	Kind string
+	// Doc is the schema's top-level description, if any.
+	Doc string
+	// Fields are the Kind's top-level properties. A property that is itself
+	// an object, or an array of objects, contributes one Field whose Nested
+	// holds that object's own Fields, so arbitrarily deep schemas round-trip
+	// the same way a gengo-parsed struct field referencing another struct
+	// would.
+	Fields []Field
+	// Served and Storage mirror the originating CRD version's flags, since
+	// a Kind can appear under more than one version with different status.
+	Served  bool
+	Storage bool
+}
+
+// Field is a single property of a Type, derived from a JSONSchemaProps
+// entry.
+type Field struct {
+	Name     string
+	Doc      string
+	Type     string
+	Required bool
+
+	// Nested holds the properties of this field when Type is "object", or
+	// the properties of each element when Type is "array" and its items are
+	// themselves objects. It is nil for scalar and opaque fields.
+	Nested *Type
+}
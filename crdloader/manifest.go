@@ -0,0 +1,103 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package crdloader
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	"sigs.k8s.io/yaml"
+)
+
+// ManifestLoader loads CustomResourceDefinitions from a directory of YAML
+// manifests, letting docs be generated offline for CRDs that aren't
+// installed in any reachable cluster.
+type ManifestLoader struct {
+	dir string
+}
+
+// NewManifestLoader builds a ManifestLoader that reads every *.yaml/*.yml
+// file directly under dir.
+func NewManifestLoader(dir string) *ManifestLoader {
+	return &ManifestLoader{dir: dir}
+}
+
+// Load reads and converts every CustomResourceDefinition manifest in the
+// loader's directory. Files that don't contain a CustomResourceDefinition
+// are skipped.
+func (l *ManifestLoader) Load(_ context.Context) ([]GroupVersionDetails, error) {
+	entries, err := os.ReadDir(l.dir)
+	if err != nil {
+		return nil, fmt.Errorf("reading CRD manifest directory %q: %w", l.dir, err)
+	}
+
+	var details []GroupVersionDetails
+	for _, entry := range entries {
+		if entry.IsDir() || !isYAMLFile(entry.Name()) {
+			continue
+		}
+
+		path := filepath.Join(l.dir, entry.Name())
+		crd, err := readCRDManifest(path)
+		if err != nil {
+			return nil, fmt.Errorf("reading CRD manifest %q: %w", path, err)
+		}
+		if crd == nil {
+			continue
+		}
+
+		details = append(details, groupVersionsFromCRD(crd)...)
+	}
+
+	return mergeGroupVersions(details), nil
+}
+
+func isYAMLFile(name string) bool {
+	ext := filepath.Ext(name)
+	return ext == ".yaml" || ext == ".yml"
+}
+
+// readCRDManifest reads a single manifest file and returns its
+// CustomResourceDefinition, or nil if the manifest's kind isn't
+// CustomResourceDefinition.
+func readCRDManifest(path string) (*apiextensionsv1.CustomResourceDefinition, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var typeMeta struct {
+		Kind string `json:"kind"`
+	}
+	if err := yaml.Unmarshal(data, &typeMeta); err != nil {
+		return nil, err
+	}
+	if strings.TrimSpace(typeMeta.Kind) != "CustomResourceDefinition" {
+		return nil, nil
+	}
+
+	var crd apiextensionsv1.CustomResourceDefinition
+	if err := yaml.Unmarshal(data, &crd); err != nil {
+		return nil, err
+	}
+	return &crd, nil
+}
@@ -0,0 +1,123 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package crdloader
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+const widgetCRDManifest = `
+apiVersion: apiextensions.k8s.io/v1
+kind: CustomResourceDefinition
+metadata:
+  name: widgets.example.com
+spec:
+  group: example.com
+  names:
+    kind: Widget
+    plural: widgets
+  scope: Namespaced
+  versions:
+    - name: v1
+      served: true
+      storage: true
+      schema:
+        openAPIV3Schema:
+          description: Widget is a sample resource.
+          type: object
+          required:
+            - size
+          properties:
+            size:
+              type: integer
+              description: Size of the widget.
+            color:
+              type: string
+              description: Color of the widget.
+`
+
+const notACRDManifest = `
+apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: not-a-crd
+data:
+  foo: bar
+`
+
+func writeManifest(t *testing.T, dir, name, content string) {
+	t.Helper()
+	if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0o644); err != nil {
+		t.Fatalf("writing fixture %s: %v", name, err)
+	}
+}
+
+func TestManifestLoaderLoad(t *testing.T) {
+	dir := t.TempDir()
+	writeManifest(t, dir, "widget.yaml", widgetCRDManifest)
+	writeManifest(t, dir, "configmap.yaml", notACRDManifest)
+	writeManifest(t, dir, "README.md", "not yaml at all")
+
+	loader := NewManifestLoader(dir)
+	details, err := loader.Load(context.Background())
+	if err != nil {
+		t.Fatalf("Load() returned error: %v", err)
+	}
+
+	if len(details) != 1 {
+		t.Fatalf("expected 1 GroupVersionDetails, got %d", len(details))
+	}
+
+	gvd := details[0]
+	wantGV := schema.GroupVersion{Group: "example.com", Version: "v1"}
+	if gvd.GroupVersion != wantGV {
+		t.Errorf("GroupVersion = %v, want %v", gvd.GroupVersion, wantGV)
+	}
+	if len(gvd.Types) != 1 {
+		t.Fatalf("expected 1 Type, got %d", len(gvd.Types))
+	}
+
+	typ := gvd.Types[0]
+	if typ.Kind != "Widget" {
+		t.Errorf("Kind = %q, want %q", typ.Kind, "Widget")
+	}
+	if !typ.Served || !typ.Storage {
+		t.Errorf("Served/Storage = %v/%v, want true/true", typ.Served, typ.Storage)
+	}
+	if len(typ.Fields) != 2 {
+		t.Fatalf("expected 2 fields, got %d", len(typ.Fields))
+	}
+	if typ.Fields[0].Name != "color" || typ.Fields[0].Required {
+		t.Errorf("Fields[0] = %+v, want color/not required", typ.Fields[0])
+	}
+	if typ.Fields[1].Name != "size" || !typ.Fields[1].Required {
+		t.Errorf("Fields[1] = %+v, want size/required", typ.Fields[1])
+	}
+}
+
+func TestManifestLoaderMissingDirectory(t *testing.T) {
+	loader := NewManifestLoader(filepath.Join(t.TempDir(), "does-not-exist"))
+	if _, err := loader.Load(context.Background()); err == nil {
+		t.Fatal("expected an error loading a missing directory, got nil")
+	}
+}
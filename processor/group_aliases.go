@@ -0,0 +1,103 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package processor
+
+import "k8s.io/apimachinery/pkg/runtime/schema"
+
+// GroupAliasSet is a set of group names treated as equivalent when sorting
+// and rendering, e.g. a group renamed during a migration and its mirror
+// under another domain. The first entry is the canonical name used in their
+// place.
+type GroupAliasSet []string
+
+// AliasesConfig is the config-file shape for group aliases: a list of
+// equivalence sets, each a list of group names, e.g.
+//
+//	aliases:
+//	  - ["networking.k8s.io", "networking.gke.io"]
+//
+// GroupAliasSets converts it to the []GroupAliasSet that CanonicalGroup and
+// compareGroupVersionsFunctionWithAliases expect.
+type AliasesConfig struct {
+	Aliases [][]string `json:"aliases,omitempty" yaml:"aliases,omitempty"`
+}
+
+// GroupAliasSets converts c into the []GroupAliasSet consumed by
+// CanonicalGroup, compareGroupVersionsFunctionWithAliases, and
+// crdloader.MergeAliasedGroups.
+func (c AliasesConfig) GroupAliasSets() []GroupAliasSet {
+	if len(c.Aliases) == 0 {
+		return nil
+	}
+	sets := make([]GroupAliasSet, len(c.Aliases))
+	for i, set := range c.Aliases {
+		sets[i] = GroupAliasSet(set)
+	}
+	return sets
+}
+
+// CanonicalGroup returns the canonical name for group: the first entry of
+// the GroupAliasSet group belongs to, or group itself when aliasSets
+// doesn't mention it.
+func CanonicalGroup(group string, aliasSets []GroupAliasSet) string {
+	for _, set := range aliasSets {
+		if len(set) == 0 {
+			continue
+		}
+		for _, alias := range set {
+			if alias == group {
+				return set[0]
+			}
+		}
+	}
+	return group
+}
+
+// compareGroupVersionsFunctionWithAliases is compareGroupVersionsFunction's
+// counterpart for configurations with group aliases: both groups are
+// resolved to their canonical form via CanonicalGroup before the usual
+// pattern-based comparison runs, so an entire alias equivalence class sorts
+// as a single node in compareGroupsHierarchically.
+func compareGroupVersionsFunctionWithAliases(groupVersions []schema.GroupVersion, patterns []string, aliasSets []GroupAliasSet) func(i, j int) bool {
+	return func(i, j int) bool {
+		gv1 := groupVersions[i]
+		gv2 := groupVersions[j]
+		gv1.Group = CanonicalGroup(gv1.Group, aliasSets)
+		gv2.Group = CanonicalGroup(gv2.Group, aliasSets)
+		return compareGroupVersionPair(gv1, gv2, patterns) < 0
+	}
+}
+
+// compareGroupVersionsFunctionWithRulesAndAliases is the combined
+// counterpart for configs that need both structured GroupSortRules and
+// group aliases at once, e.g. an aliased group that also needs an explicit
+// priority bucket: both groups are resolved to their canonical form via
+// CanonicalGroup, the same way compareGroupVersionsFunctionWithAliases does,
+// before compareGroupVersionPairWithRules applies rules' Priority/
+// VersionPriority. A GroupSortRule's Pattern should therefore be written
+// against the alias set's canonical name, since that's what it's matched
+// against here.
+func compareGroupVersionsFunctionWithRulesAndAliases(groupVersions []schema.GroupVersion, rules []GroupSortRule, aliasSets []GroupAliasSet) func(i, j int) bool {
+	return func(i, j int) bool {
+		gv1 := groupVersions[i]
+		gv2 := groupVersions[j]
+		gv1.Group = CanonicalGroup(gv1.Group, aliasSets)
+		gv2.Group = CanonicalGroup(gv2.Group, aliasSets)
+		return compareGroupVersionPairWithRules(gv1, gv2, rules) < 0
+	}
+}
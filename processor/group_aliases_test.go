@@ -0,0 +1,142 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package processor
+
+import (
+	"sort"
+	"testing"
+
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+func TestCanonicalGroup(t *testing.T) {
+	aliasSets := []GroupAliasSet{
+		{"networking.k8s.io", "networking.gke.io"},
+		{"batch.k8s.io"},
+	}
+
+	tests := []struct {
+		name  string
+		group string
+		want  string
+	}{
+		{"canonical member unchanged", "networking.k8s.io", "networking.k8s.io"},
+		{"alias resolves to canonical", "networking.gke.io", "networking.k8s.io"},
+		{"singleton set resolves to itself", "batch.k8s.io", "batch.k8s.io"},
+		{"unaliased group unchanged", "apps.k8s.io", "apps.k8s.io"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := CanonicalGroup(tt.group, aliasSets); got != tt.want {
+				t.Errorf("CanonicalGroup(%q) = %q, want %q", tt.group, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestAliasesConfigGroupAliasSets(t *testing.T) {
+	cfg := AliasesConfig{
+		Aliases: [][]string{
+			{"networking.k8s.io", "networking.gke.io"},
+			{"batch.k8s.io"},
+		},
+	}
+
+	got := cfg.GroupAliasSets()
+	want := []GroupAliasSet{
+		{"networking.k8s.io", "networking.gke.io"},
+		{"batch.k8s.io"},
+	}
+
+	if len(got) != len(want) {
+		t.Fatalf("GroupAliasSets() = %v, want %v", got, want)
+	}
+	for i := range want {
+		if len(got[i]) != len(want[i]) {
+			t.Fatalf("GroupAliasSets()[%d] = %v, want %v", i, got[i], want[i])
+		}
+		for j := range want[i] {
+			if got[i][j] != want[i][j] {
+				t.Errorf("GroupAliasSets()[%d][%d] = %q, want %q", i, j, got[i][j], want[i][j])
+			}
+		}
+	}
+}
+
+func TestAliasesConfigGroupAliasSetsEmpty(t *testing.T) {
+	var cfg AliasesConfig
+	if got := cfg.GroupAliasSets(); got != nil {
+		t.Errorf("GroupAliasSets() on empty config = %v, want nil", got)
+	}
+}
+
+func TestCompareGroupVersionsFunctionWithAliasesMergesEquivalenceClass(t *testing.T) {
+	// networking.k8s.io and networking.gke.io are aliases of one another, so
+	// they should interleave as if they were both named "networking.k8s.io",
+	// rather than sorting as two separate hierarchical nodes.
+	aliasSets := []GroupAliasSet{
+		{"networking.k8s.io", "networking.gke.io"},
+	}
+
+	groupVersions := []schema.GroupVersion{
+		{Group: "storage.k8s.io", Version: "v1"},
+		{Group: "networking.gke.io", Version: "v1"},
+		{Group: "apps.k8s.io", Version: "v1"},
+		{Group: "networking.k8s.io", Version: "v1beta1"},
+	}
+
+	sort.SliceStable(groupVersions, compareGroupVersionsFunctionWithAliases(groupVersions, []string{}, aliasSets))
+
+	expected := []schema.GroupVersion{
+		{Group: "apps.k8s.io", Version: "v1"},
+		{Group: "networking.gke.io", Version: "v1"},     // canonical "networking.k8s.io", GA before beta
+		{Group: "networking.k8s.io", Version: "v1beta1"}, // canonical "networking.k8s.io", beta after GA
+		{Group: "storage.k8s.io", Version: "v1"},
+	}
+
+	assertSortOrder(t, groupVersions, expected)
+}
+
+func TestCompareGroupVersionsFunctionWithRulesAndAliasesAppliesPriorityToCanonicalName(t *testing.T) {
+	// "networking.gke.io" is an alias of "networking.k8s.io"; a priority
+	// rule written against the canonical name should still pull the
+	// aliased entry ahead of groups that would otherwise sort first
+	// hierarchically.
+	aliasSets := []GroupAliasSet{
+		{"networking.k8s.io", "networking.gke.io"},
+	}
+	rules := []GroupSortRule{
+		{Pattern: "networking.k8s.io", Priority: 0},
+		{Pattern: "apps.k8s.io", Priority: 1},
+	}
+
+	groupVersions := []schema.GroupVersion{
+		{Group: "apps.k8s.io", Version: "v1"},
+		{Group: "networking.gke.io", Version: "v1"},
+	}
+
+	sort.SliceStable(groupVersions, compareGroupVersionsFunctionWithRulesAndAliases(groupVersions, rules, aliasSets))
+
+	expected := []schema.GroupVersion{
+		{Group: "networking.gke.io", Version: "v1"}, // canonical "networking.k8s.io", Priority 0
+		{Group: "apps.k8s.io", Version: "v1"},        // Priority 1
+	}
+
+	assertSortOrder(t, groupVersions, expected)
+}
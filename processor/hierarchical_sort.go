@@ -0,0 +1,327 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package processor
+
+import (
+	"math"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+// kubeVersionPattern matches the Kubernetes API version convention used by
+// apimachinery for APIResource discovery, e.g. "v1", "v2beta1", "v1alpha2".
+var kubeVersionPattern = regexp.MustCompile(`^v(\d+)(?:(alpha|beta)(\d+))?$`)
+
+// versionTrack ranks the release track of a Kube-style version. Lower ranks
+// sort before higher ones: GA before beta before alpha.
+type versionTrack int
+
+const (
+	trackGA versionTrack = iota
+	trackBeta
+	trackAlpha
+)
+
+// kubeVersion is a Kube-style version string decomposed into its comparable
+// parts.
+type kubeVersion struct {
+	major int
+	track versionTrack
+	pre   int
+}
+
+// parseKubeVersion decomposes a version string per the convention
+// `^v(\d+)(?:(alpha|beta)(\d+))?$`. ok is false when the string doesn't
+// match, in which case it is treated as a "custom" version that sorts
+// lexically after every recognized version.
+func parseKubeVersion(v string) (parsed kubeVersion, ok bool) {
+	m := kubeVersionPattern.FindStringSubmatch(v)
+	if m == nil {
+		return kubeVersion{}, false
+	}
+
+	major, err := strconv.Atoi(m[1])
+	if err != nil {
+		return kubeVersion{}, false
+	}
+
+	parsed = kubeVersion{major: major, track: trackGA}
+	if m[2] == "" {
+		return parsed, true
+	}
+
+	pre, err := strconv.Atoi(m[3])
+	if err != nil {
+		return kubeVersion{}, false
+	}
+	parsed.pre = pre
+	if m[2] == "beta" {
+		parsed.track = trackBeta
+	} else {
+		parsed.track = trackAlpha
+	}
+	return parsed, true
+}
+
+// compareVersions orders two API versions the way apimachinery orders them
+// for APIResource discovery: GA sorts before beta which sorts before alpha;
+// within the same track the leading integer is compared numerically, then
+// the pre-release integer. Versions that don't match the Kube convention are
+// treated as custom and sort lexically after all recognized versions. The
+// result is -1, 0 or 1.
+func compareVersions(v1, v2 string) int {
+	p1, ok1 := parseKubeVersion(v1)
+	p2, ok2 := parseKubeVersion(v2)
+
+	switch {
+	case ok1 && !ok2:
+		return -1
+	case !ok1 && ok2:
+		return 1
+	case !ok1 && !ok2:
+		return strings.Compare(v1, v2)
+	}
+
+	var d int
+	switch {
+	case p1.track != p2.track:
+		d = int(p1.track) - int(p2.track)
+	case p1.major != p2.major:
+		d = p1.major - p2.major
+	default:
+		d = p1.pre - p2.pre
+	}
+	switch {
+	case d < 0:
+		return -1
+	case d > 0:
+		return 1
+	}
+	return 0
+}
+
+// patternMatches reports whether pattern selects group: an exact match, a
+// parent-domain match (pattern "k8s.io" matches group "apps.k8s.io"), the
+// empty pattern matching only the core (empty) group, or "*" matching any
+// group.
+func patternMatches(group, pattern string) bool {
+	switch pattern {
+	case "*":
+		return true
+	case "":
+		return group == ""
+	default:
+		return group == pattern || strings.HasSuffix(group, "."+pattern)
+	}
+}
+
+// getGroupPriority returns the index of the first pattern that matches
+// group (see patternMatches). Groups that match no pattern get an implicit
+// priority of len(patterns), sorting after every explicitly configured
+// bucket.
+func getGroupPriority(group string, patterns []string) int {
+	for i, pattern := range patterns {
+		if patternMatches(group, pattern) {
+			return i
+		}
+	}
+	return len(patterns)
+}
+
+// GroupSortRule assigns an explicit numeric sort priority to a group
+// pattern, mirroring how an APIService carries Group/GroupPriorityMinimum
+// and Version/VersionPriority for a single (group, version) pair. Lower
+// Priority values sort first; rules that tie on Priority fall back to
+// compareGroupsHierarchically. Pattern is matched against a group the same
+// way getGroupPriority matches patterns (see patternMatches), so a trailing
+// {Pattern: "*"} rule can be used to override the default priority given to
+// groups that otherwise match nothing.
+//
+// Version, when non-empty, scopes the rule to VersionPriority instead of
+// Priority: it doesn't contribute to a group's bucket, but ranks that exact
+// version (e.g. "v2") ahead of or behind its siblings within whichever group
+// Pattern matches, overriding the default Kube-aware version comparison.
+// Lower VersionPriority values sort first; versions with no matching rule
+// fall back to compareVersions, and sort after any version that has one.
+type GroupSortRule struct {
+	Pattern  string
+	Priority int32
+
+	Version         string
+	VersionPriority int32
+}
+
+// DefaultOtherGroupPriority is the Priority assigned to a group matching no
+// GroupSortRule, unless overridden by a wildcard ("*") rule.
+const DefaultOtherGroupPriority int32 = math.MaxInt32
+
+// getGroupPriorityFromRules returns the Priority of the first group-level
+// rule (Version == "") matching group, or DefaultOtherGroupPriority if none
+// matches. Version-scoped rules don't affect group priority.
+func getGroupPriorityFromRules(group string, rules []GroupSortRule) int32 {
+	for _, rule := range rules {
+		if rule.Version != "" {
+			continue
+		}
+		if patternMatches(group, rule.Pattern) {
+			return rule.Priority
+		}
+	}
+	return DefaultOtherGroupPriority
+}
+
+// getVersionPriority returns the VersionPriority of the first rule whose
+// Pattern matches group and whose Version exactly equals version. ok is
+// false when no such rule exists, in which case the caller should fall back
+// to compareVersions.
+func getVersionPriority(group, version string, rules []GroupSortRule) (priority int32, ok bool) {
+	for _, rule := range rules {
+		if rule.Version != version {
+			continue
+		}
+		if patternMatches(group, rule.Pattern) {
+			return rule.VersionPriority, true
+		}
+	}
+	return 0, false
+}
+
+// compareVersionsWithRules is compareVersions' counterpart for versions
+// belonging to the same group: a rule's explicit VersionPriority, when
+// present for a version, wins over the default Kube-aware comparison; a
+// version with an explicit priority always sorts before one without.
+func compareVersionsWithRules(group, v1, v2 string, rules []GroupSortRule) int {
+	p1, ok1 := getVersionPriority(group, v1, rules)
+	p2, ok2 := getVersionPriority(group, v2, rules)
+
+	switch {
+	case ok1 && ok2:
+		if p1 != p2 {
+			return int(p1) - int(p2)
+		}
+	case ok1 && !ok2:
+		return -1
+	case !ok1 && ok2:
+		return 1
+	}
+	return compareVersions(v1, v2)
+}
+
+// compareGroupsHierarchically orders two API groups by domain hierarchy: a
+// parent domain (e.g. "k8s.io") sorts before its subdomains (e.g.
+// "apps.k8s.io"), and siblings sort alphabetically. patterns assigns
+// explicit priority buckets (see getGroupPriority); groups in different
+// buckets are ordered by bucket regardless of hierarchy.
+func compareGroupsHierarchically(group1, group2 string, patterns []string) int {
+	if len(patterns) > 0 {
+		p1 := getGroupPriority(group1, patterns)
+		p2 := getGroupPriority(group2, patterns)
+		if p1 != p2 {
+			return p1 - p2
+		}
+	}
+
+	if group1 == group2 {
+		return 0
+	}
+
+	labels1 := reverseDomainLabels(group1)
+	labels2 := reverseDomainLabels(group2)
+
+	for i := 0; i < len(labels1) && i < len(labels2); i++ {
+		if labels1[i] != labels2[i] {
+			return strings.Compare(labels1[i], labels2[i])
+		}
+	}
+	// One is a parent domain of the other; the parent sorts first.
+	return len(labels1) - len(labels2)
+}
+
+// compareGroupsHierarchicallyWithRules is compareGroupsHierarchically's
+// counterpart for structured rules: groups are ordered by Priority first,
+// and any tie falls back to compareGroupsHierarchically's
+// hierarchical/alphabetical comparison.
+func compareGroupsHierarchicallyWithRules(group1, group2 string, rules []GroupSortRule) int {
+	p1 := getGroupPriorityFromRules(group1, rules)
+	p2 := getGroupPriorityFromRules(group2, rules)
+	if p1 != p2 {
+		return int(p1) - int(p2)
+	}
+	return compareGroupsHierarchically(group1, group2, nil)
+}
+
+// reverseDomainLabels splits a dotted domain into its labels ordered from
+// top-level domain to subdomain, so that common ancestry can be compared
+// label by label. The core (empty) group yields a single empty label, which
+// sorts before every named group.
+func reverseDomainLabels(group string) []string {
+	if group == "" {
+		return []string{""}
+	}
+	labels := strings.Split(group, ".")
+	for i, j := 0, len(labels)-1; i < j; i, j = i+1, j-1 {
+		labels[i], labels[j] = labels[j], labels[i]
+	}
+	return labels
+}
+
+// compareGroupVersionPair orders two GroupVersions by group, using
+// compareGroupsHierarchically with patterns as the explicit priority
+// buckets, falling back to compareVersions when the groups are equal.
+func compareGroupVersionPair(gv1, gv2 schema.GroupVersion, patterns []string) int {
+	if c := compareGroupsHierarchically(gv1.Group, gv2.Group, patterns); c != 0 {
+		return c
+	}
+	return compareVersions(gv1.Version, gv2.Version)
+}
+
+// compareGroupVersionsFunction returns a sort.SliceStable-compatible Less
+// function over groupVersions, ordering entries first by group and then, for
+// entries sharing a group, by version. patterns assigns explicit priority
+// buckets to groups; see compareGroupsHierarchically.
+func compareGroupVersionsFunction(groupVersions []schema.GroupVersion, patterns []string) func(i, j int) bool {
+	return func(i, j int) bool {
+		return compareGroupVersionPair(groupVersions[i], groupVersions[j], patterns) < 0
+	}
+}
+
+// compareGroupVersionPairWithRules is compareGroupVersionPair's counterpart
+// for structured GroupSortRules: groups are ordered via
+// compareGroupsHierarchicallyWithRules, and entries sharing a group are
+// ordered via compareVersionsWithRules so a rule's VersionPriority can
+// override the default Kube-aware version ordering.
+func compareGroupVersionPairWithRules(gv1, gv2 schema.GroupVersion, rules []GroupSortRule) int {
+	if c := compareGroupsHierarchicallyWithRules(gv1.Group, gv2.Group, rules); c != 0 {
+		return c
+	}
+	return compareVersionsWithRules(gv1.Group, gv1.Version, gv2.Version, rules)
+}
+
+// compareGroupVersionsFunctionWithRules is compareGroupVersionsFunction's
+// counterpart for callers that need finer-grained control than a plain
+// pattern list offers, such as assigning two disjoint domains the same
+// priority bucket: it accepts structured GroupSortRules instead of a
+// []string pattern list.
+func compareGroupVersionsFunctionWithRules(groupVersions []schema.GroupVersion, rules []GroupSortRule) func(i, j int) bool {
+	return func(i, j int) bool {
+		return compareGroupVersionPairWithRules(groupVersions[i], groupVersions[j], rules) < 0
+	}
+}
@@ -92,20 +92,20 @@ func TestHierarchicalSortingIntegration(t *testing.T) {
 	}
 
 	// Sort using hierarchical comparison with no special patterns
-	sort.SliceStable(groupVersions, compareGroupVersionsFunction([]string{}))
+	sort.SliceStable(groupVersions, compareGroupVersionsFunction(groupVersions, []string{}))
 
 	expected := []schema.GroupVersion{
 		{Group: "", Version: "v1"},                                    // core group (empty string sorts first alphabetically)
 		{Group: "example.com", Version: "v1"},                        // example.com parent domain
 		{Group: "test.example.com", Version: "v1"},                   // example.com subdomain
 		{Group: "k8s.io", Version: "v1"},                             // k8s.io parent domain
-		{Group: "apps.k8s.io", Version: "v1"},                        // k8s.io subdomain, v1 before v1beta1
-		{Group: "apps.k8s.io", Version: "v1beta1"},                   // k8s.io subdomain, v1beta1 after v1
+		{Group: "apps.k8s.io", Version: "v1"},                        // k8s.io subdomain, GA before beta
+		{Group: "apps.k8s.io", Version: "v1beta1"},                   // k8s.io subdomain, beta after GA
 		{Group: "batch.k8s.io", Version: "v1"},                       // k8s.io sibling to apps
 		{Group: "events.k8s.io", Version: "v1"},                      // k8s.io sibling to apps and batch
 		{Group: "networking.k8s.io", Version: "v1"},                  // k8s.io sibling to apps, batch, events
-		{Group: "gateway.networking.k8s.io", Version: "v1alpha2"},    // k8s.io nested subdomain, v1alpha2 before v1beta1
-		{Group: "gateway.networking.k8s.io", Version: "v1beta1"},     // k8s.io nested subdomain, v1beta1 after v1alpha2
+		{Group: "gateway.networking.k8s.io", Version: "v1beta1"},     // k8s.io nested subdomain, beta before alpha
+		{Group: "gateway.networking.k8s.io", Version: "v1alpha2"},    // k8s.io nested subdomain, alpha after beta
 		{Group: "storage.k8s.io", Version: "v1"},                     // k8s.io sibling to networking
 		{Group: "x-k8s.io", Version: "v1"},                           // x-k8s.io parent domain
 		{Group: "metrics.x-k8s.io", Version: "v1beta1"},              // x-k8s.io subdomain
@@ -132,7 +132,7 @@ func TestCustomGroupSort(t *testing.T) {
 	}
 
 	// Sort using custom patterns
-	sort.SliceStable(groupVersions, compareGroupVersionsFunction(customSortPatterns))
+	sort.SliceStable(groupVersions, compareGroupVersionsFunction(groupVersions, customSortPatterns))
 
 	expected := []schema.GroupVersion{
 		{Group: "custom.example.com", Version: "v1"},     // custom priority 0
@@ -204,3 +204,116 @@ func TestMultiplePatternPriorities(t *testing.T) {
 		})
 	}
 }
+
+func TestGroupSortRulesSharedPriorityFallsBackToHierarchical(t *testing.T) {
+	// Two disjoint domains sharing a priority bucket: ties are decided by
+	// hierarchical/alphabetical comparison, same as an unconfigured sort.
+	rules := []GroupSortRule{
+		{Pattern: "apps.k8s.io", Priority: 0},
+		{Pattern: "example.com", Priority: 0},
+		{Pattern: "batch.k8s.io", Priority: 1},
+	}
+
+	groupVersions := []schema.GroupVersion{
+		{Group: "batch.k8s.io", Version: "v1"},
+		{Group: "example.com", Version: "v1"},
+		{Group: "apps.k8s.io", Version: "v1"},
+	}
+
+	sort.SliceStable(groupVersions, compareGroupVersionsFunctionWithRules(groupVersions, rules))
+
+	expected := []schema.GroupVersion{
+		{Group: "example.com", Version: "v1"},   // priority 0, .com sorts before .io hierarchically
+		{Group: "apps.k8s.io", Version: "v1"},   // priority 0, .io sorts after .com
+		{Group: "batch.k8s.io", Version: "v1"},  // priority 1
+	}
+
+	assertSortOrder(t, groupVersions, expected)
+}
+
+func TestGroupSortRulesVersionPriorityOrdersVersionsWithinGroup(t *testing.T) {
+	// An explicit VersionPriority overrides the default Kube-aware version
+	// ordering (which would otherwise sort v1 before v1alpha1) within the
+	// group the rule's Pattern matches.
+	rules := []GroupSortRule{
+		{Pattern: "apps.k8s.io", Version: "v1alpha1", VersionPriority: 0},
+		{Pattern: "apps.k8s.io", Version: "v1", VersionPriority: 1},
+	}
+
+	groupVersions := []schema.GroupVersion{
+		{Group: "apps.k8s.io", Version: "v1"},
+		{Group: "apps.k8s.io", Version: "v1alpha1"},
+	}
+
+	sort.SliceStable(groupVersions, compareGroupVersionsFunctionWithRules(groupVersions, rules))
+
+	expected := []schema.GroupVersion{
+		{Group: "apps.k8s.io", Version: "v1alpha1"}, // VersionPriority 0
+		{Group: "apps.k8s.io", Version: "v1"},       // VersionPriority 1
+	}
+
+	assertSortOrder(t, groupVersions, expected)
+}
+
+func TestGroupSortRulesVersionPriorityScopedToGroup(t *testing.T) {
+	// A VersionPriority rule only applies within the group its Pattern
+	// matches; the same version in a different group still falls back to
+	// compareVersions.
+	rules := []GroupSortRule{
+		{Pattern: "apps.k8s.io", Version: "v1alpha1", VersionPriority: 0},
+	}
+
+	result := compareVersionsWithRules("batch.k8s.io", "v1alpha1", "v1", rules)
+	want := compareVersions("v1alpha1", "v1")
+	if result != want {
+		t.Errorf("compareVersionsWithRules(batch.k8s.io, v1alpha1, v1) = %d, want %d (unscoped default)", result, want)
+	}
+}
+
+func TestGroupSortRulesUnmatchedGroup(t *testing.T) {
+	rules := []GroupSortRule{{Pattern: "k8s.io", Priority: 0}}
+
+	priority := getGroupPriorityFromRules("example.com", rules)
+	if priority != DefaultOtherGroupPriority {
+		t.Errorf("getGroupPriorityFromRules(example.com, %v) = %d, want %d", rules, priority, DefaultOtherGroupPriority)
+	}
+}
+
+func TestCompareVersions(t *testing.T) {
+	tests := []struct {
+		name     string
+		v1       string
+		v2       string
+		expected int
+	}{
+		// Numeric comparison of the leading integer, not lexical
+		{"GA major versions numeric", "v2", "v10", -1},
+		{"beta pre-release numeric", "v1beta2", "v1beta10", -1},
+
+		// Cross-track comparison is decided by the track before the major version
+		{"GA major beats beta track", "v1", "v2beta1", -1},
+
+		// Within the same major version, GA sorts before beta before alpha
+		{"GA before beta", "v1", "v1beta1", -1},
+		{"beta before alpha", "v1beta1", "v1alpha1", -1},
+		{"GA before alpha", "v1", "v1alpha1", -1},
+
+		// Custom, unrecognized versions sort lexically after all Kube-style versions
+		{"custom sorts after GA", "v1", "v1foo", -1},
+		{"custom sorts after beta", "v1beta1", "v1foo", -1},
+		{"custom sorts after alpha", "v1alpha1", "v1foo", -1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := compareVersions(tt.v1, tt.v2)
+			if result != tt.expected {
+				t.Errorf("compareVersions(%q, %q) = %d, want %d", tt.v1, tt.v2, result, tt.expected)
+			}
+			reversed := compareVersions(tt.v2, tt.v1)
+			if (tt.expected < 0 && reversed <= 0) || (tt.expected > 0 && reversed >= 0) {
+				t.Errorf("compareVersions(%q, %q) = %d, not the inverse of compareVersions(%q, %q) = %d", tt.v2, tt.v1, reversed, tt.v1, tt.v2, result)
+			}
+		})
+	}
+}